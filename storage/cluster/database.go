@@ -21,6 +21,7 @@
 package cluster
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -62,8 +63,26 @@ type clusterDB struct {
 	doneCh     chan struct{}
 	closedCh   chan struct{}
 
-	initializing   map[uint32]shard.Shard
-	bootstrapCount map[uint32]int
+	warnDoneCh chan struct{}
+
+	events           *shardEventBroker
+	bootstrapEventCh chan struct{}
+
+	initializing    map[uint32]shard.Shard
+	initializingCtx map[uint32]shardInitCtx
+	eligibleSince   map[uint32]time.Time
+
+	startupProgress         StartupProgress
+	shardAvailabilityPolicy ShardAvailabilityPolicy
+}
+
+// shardInitCtx tracks the cancellable context handed to the storage layer
+// for a shard that is currently bootstrapping on this host, so that a
+// topology change which reassigns the shard away can cancel the in-flight
+// bootstrap rather than letting it run to completion.
+type shardInitCtx struct {
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewDatabase creates a new clustered time series database
@@ -72,6 +91,7 @@ func NewDatabase(
 	hostID string,
 	topoInit topology.Initializer,
 	opts storage.Options,
+	clusterOpts ClusterOptions,
 ) (Database, error) {
 	log := opts.InstrumentOptions().Logger()
 	topo, err := topoInit.Init()
@@ -87,13 +107,31 @@ func NewDatabase(
 	// Wait for the topology to be available
 	<-watch.C()
 
+	if clusterOpts == nil {
+		clusterOpts = NewClusterOptions()
+	}
+	startupProgress := clusterOpts.StartupProgress()
+	if startupProgress == nil {
+		startupProgress = NewLogStartupProgress(log)
+	}
+	shardAvailabilityPolicy := clusterOpts.ShardAvailabilityPolicy()
+	if shardAvailabilityPolicy == nil {
+		shardAvailabilityPolicy = AllNamespacesPolicy()
+	}
+
 	d := &clusterDB{
-		log:            log,
-		hostID:         hostID,
-		topo:           topo,
-		watch:          watch,
-		initializing:   make(map[uint32]shard.Shard),
-		bootstrapCount: make(map[uint32]int),
+		log:                     log,
+		hostID:                  hostID,
+		topo:                    topo,
+		watch:                   watch,
+		warnDoneCh:              make(chan struct{}),
+		events:                  newShardEventBroker(hostID),
+		bootstrapEventCh:        make(chan struct{}, 1),
+		initializing:            make(map[uint32]shard.Shard),
+		initializingCtx:         make(map[uint32]shardInitCtx),
+		eligibleSince:           make(map[uint32]time.Time),
+		startupProgress:         startupProgress,
+		shardAvailabilityPolicy: shardAvailabilityPolicy,
 	}
 
 	shardSet := d.hostOrEmptyShardSet(watch.Get())
@@ -103,17 +141,43 @@ func NewDatabase(
 	}
 
 	d.Database = db
+	d.Database.RegisterBootstrapListener(d.onBootstrapEvent)
+
+	go d.maybeWarnAboutInit()
+
 	return d, nil
 }
 
+// onBootstrapEvent is registered with the underlying storage.Database as a
+// bootstrap completion listener. It wakes up activeTopologyWatch so that
+// shard availability is re-evaluated as soon as a bootstrap finishes,
+// instead of waiting on a fixed polling interval.
+func (d *clusterDB) onBootstrapEvent(shardID uint32, namespaceID string) {
+	select {
+	case d.bootstrapEventCh <- struct{}{}:
+	default:
+		// A re-analysis is already pending, no need to queue another.
+	}
+}
+
+// SubscribeShardEvents implements Database.
+func (d *clusterDB) SubscribeShardEvents(replayLastState bool) (<-chan ShardEvent, func()) {
+	return d.events.subscribe(replayLastState)
+}
+
 func (d *clusterDB) Open() error {
 	select {
 	case <-d.watch.C():
 		shardSet := d.hostOrEmptyShardSet(d.watch.Get())
-		d.Database.AssignShardSet(shardSet)
+		d.assignShardSet(shardSet)
 	default:
 		// No updates to the topology since cluster DB created
 	}
+	for _, s := range d.hostOrEmptyShardSet(d.watch.Get()).All() {
+		if s.State() == shard.Initializing {
+			d.startupProgress.AddShard(s.ID())
+		}
+	}
 	if err := d.Database.Open(); err != nil {
 		return err
 	}
@@ -121,6 +185,8 @@ func (d *clusterDB) Open() error {
 }
 
 func (d *clusterDB) Close() error {
+	close(d.warnDoneCh)
+	d.startupProgress.Close()
 	if err := d.Database.Close(); err != nil {
 		return err
 	}
@@ -162,39 +228,123 @@ func (d *clusterDB) stopActiveTopologyWatch() error {
 }
 
 func (d *clusterDB) activeTopologyWatch() {
-	reportClosingCh := make(chan struct{}, 1)
-	reportClosedCh := make(chan struct{}, 1)
-	go func() {
-		ticker := time.NewTicker(time.Second)
-		for {
+	// dwellTimer fires purely to re-run analyzeAndReportShardStates once a
+	// shard's MinBootstrapDwellTime elapses, since that is otherwise never
+	// triggered by a topology update or bootstrap completion.
+	dwellTimer := time.NewTimer(0)
+	if !dwellTimer.Stop() {
+		<-dwellTimer.C
+	}
+	defer dwellTimer.Stop()
+
+	rearmDwellTimer := func() {
+		if !dwellTimer.Stop() {
 			select {
-			case <-ticker.C:
-				d.analyzeAndReportShardStates()
-			case <-reportClosingCh:
-				ticker.Stop()
-				close(reportClosedCh)
-				return
+			case <-dwellTimer.C:
+			default:
 			}
 		}
-	}()
+		if wait, ok := d.nextDwellCheck(); ok {
+			dwellTimer.Reset(wait)
+		}
+	}
 
 	for {
 		select {
 		case <-d.doneCh:
-			// Issue closing signal to report channel
-			close(reportClosingCh)
-			// Wait for report channel to close
-			<-reportClosedCh
-			// Signal all closed
 			close(d.closedCh)
 			return
 		case <-d.watch.C():
 			shardSet := d.hostOrEmptyShardSet(d.watch.Get())
-			d.Database.AssignShardSet(shardSet)
+			d.assignShardSet(shardSet)
+			d.analyzeAndReportShardStates()
+			rearmDwellTimer()
+		case <-d.bootstrapEventCh:
+			d.analyzeAndReportShardStates()
+			rearmDwellTimer()
+		case <-dwellTimer.C:
+			d.analyzeAndReportShardStates()
+			rearmDwellTimer()
 		}
 	}
 }
 
+// nextDwellCheck returns how long to wait before re-running
+// analyzeAndReportShardStates purely to pick up a shard whose
+// MinBootstrapDwellTime is about to elapse, and whether any such shard is
+// currently pending.
+func (d *clusterDB) nextDwellCheck() (time.Duration, bool) {
+	dwell := d.shardAvailabilityPolicy.MinBootstrapDwellTime()
+	if dwell <= 0 || len(d.eligibleSince) == 0 {
+		return 0, false
+	}
+
+	now := time.Now()
+	wait := dwell
+	found := false
+	for _, since := range d.eligibleSince {
+		remaining := dwell - now.Sub(since)
+		if remaining < 0 {
+			remaining = 0
+		}
+		if !found || remaining < wait {
+			wait = remaining
+			found = true
+		}
+	}
+	return wait, found
+}
+
+// assignShardSet assigns a new shard set to the underlying storage database,
+// handing it a per-shard context for every shard this host is currently
+// initializing. Shards that were initializing but are no longer present (or
+// are no longer in the Initializing state) have their context cancelled so
+// that the storage layer can abandon the in-flight bootstrap instead of
+// running it to completion on data this host no longer owns.
+func (d *clusterDB) assignShardSet(shardSet sharding.ShardSet) {
+	currentState := make(map[uint32]shard.State, len(shardSet.All()))
+	stillInitializing := make(map[uint32]struct{}, len(shardSet.All()))
+	for _, s := range shardSet.All() {
+		currentState[s.ID()] = s.State()
+		if s.State() == shard.Initializing {
+			stillInitializing[s.ID()] = struct{}{}
+		}
+	}
+
+	for id, initCtx := range d.initializingCtx {
+		if _, ok := stillInitializing[id]; ok {
+			continue
+		}
+		initCtx.cancel()
+		delete(d.initializingCtx, id)
+		delete(d.eligibleSince, id)
+
+		toState, stillPresent := currentState[id]
+		if !stillPresent {
+			d.log.Infof("cancelled in-flight bootstrap for shard %d, shard removed from this host's shard set", id)
+			d.events.publishRemoved(id, shard.Initializing)
+			continue
+		}
+		d.log.Infof("cancelled in-flight bootstrap for shard %d, no longer initializing for this host", id)
+		d.events.publish(id, shard.Initializing, toState)
+	}
+
+	perShardCtx := make(map[uint32]context.Context, len(stillInitializing))
+	for id := range stillInitializing {
+		initCtx, ok := d.initializingCtx[id]
+		if !ok {
+			ctx, cancel := context.WithCancel(context.Background())
+			initCtx = shardInitCtx{ctx: ctx, cancel: cancel}
+			d.initializingCtx[id] = initCtx
+			var previous shard.State
+			d.events.publish(id, previous, shard.Initializing)
+		}
+		perShardCtx[id] = initCtx.ctx
+	}
+
+	d.Database.AssignShardSetWithContext(shardSet, perShardCtx)
+}
+
 func (d *clusterDB) analyzeAndReportShardStates() {
 	entry, ok := d.watch.Get().LookupHostShardSet(d.hostID)
 	if !ok {
@@ -202,8 +352,8 @@ func (d *clusterDB) analyzeAndReportShardStates() {
 	}
 
 	// Manage the reuseable vars
-	d.resetReuseable()
-	defer d.resetReuseable()
+	d.resetInitializing()
+	defer d.resetInitializing()
 
 	for _, s := range entry.ShardSet().All() {
 		if s.State() == shard.Initializing {
@@ -227,23 +377,39 @@ func (d *clusterDB) analyzeAndReportShardStates() {
 		return
 	}
 
-	// Count if initializing shards have bootstrapped in all namespaces
+	// Determine, per initializing shard, which namespaces have bootstrapped it
 	namespaces := d.Database.Namespaces()
+	bootstrapped := make(map[uint32]map[string]bool, len(d.initializing))
+	for id := range d.initializing {
+		bootstrapped[id] = make(map[string]bool, len(namespaces))
+	}
 	for _, n := range namespaces {
 		for _, s := range n.Shards() {
 			if _, ok := d.initializing[s.ID()]; !ok {
 				continue
 			}
-			if !s.IsBootstrapped() {
-				continue
+			bootstrapped[s.ID()][n.ID().String()] = s.IsBootstrapped()
+			if s.IsBootstrapped() {
+				d.startupProgress.ShardBootstrapped(s.ID(), n.ID().String())
 			}
-			d.bootstrapCount[s.ID()]++
 		}
 	}
 
+	allAvailable := true
 	for id := range d.initializing {
-		count := d.bootstrapCount[id]
-		if count != len(namespaces) {
+		if !d.shardAvailabilityPolicy.ShouldMarkAvailable(id, bootstrapped[id], namespaces) {
+			delete(d.eligibleSince, id)
+			allAvailable = false
+			continue
+		}
+
+		eligibleSince, ok := d.eligibleSince[id]
+		if !ok {
+			eligibleSince = time.Now()
+			d.eligibleSince[id] = eligibleSince
+		}
+		if dwell := d.shardAvailabilityPolicy.MinBootstrapDwellTime(); time.Since(eligibleSince) < dwell {
+			allAvailable = false
 			continue
 		}
 
@@ -251,15 +417,18 @@ func (d *clusterDB) analyzeAndReportShardStates() {
 		if err := topo.MarkShardAvailable(d.hostID, id); err != nil {
 			d.log.Errorf("cluster db failed marking shard %d available: %v",
 				id, err)
+			allAvailable = false
 		} else {
 			d.log.Infof("successfully marked shard %d available", id)
+			d.startupProgress.ShardAvailable(id)
+			delete(d.eligibleSince, id)
+			d.events.publish(id, shard.Initializing, shard.Available)
 		}
 	}
-}
 
-func (d *clusterDB) resetReuseable() {
-	d.resetInitializing()
-	d.resetBootstrapCount()
+	if allAvailable {
+		d.startupProgress.BootstrapCompleted()
+	}
 }
 
 func (d *clusterDB) resetInitializing() {
@@ -268,12 +437,6 @@ func (d *clusterDB) resetInitializing() {
 	}
 }
 
-func (d *clusterDB) resetBootstrapCount() {
-	for id := range d.bootstrapCount {
-		delete(d.bootstrapCount, id)
-	}
-}
-
 // hostOrEmptyShardSet returns a shard set for the given host ID from a
 // topology map and if none exists then an empty shard set. If successfully
 // found the shard set for the host the second parameter returns true,
@@ -0,0 +1,143 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3cluster/shard"
+)
+
+// shardEventBufferSize is the per-subscriber channel buffer. A subscriber
+// that falls behind by more than this many events has events dropped for it
+// rather than blocking shard state analysis.
+const shardEventBufferSize = 64
+
+// ShardEvent describes a shard state transition observed for this host. A
+// shard that has left this host's shard set entirely, rather than
+// transitioned to another State, is reported with Removed set to true; To
+// is left at its zero value in that case since there is no State to report.
+type ShardEvent struct {
+	ShardID   uint32
+	From      shard.State
+	To        shard.State
+	Removed   bool
+	HostID    string
+	Timestamp time.Time
+}
+
+// shardEventBroker fans out ShardEvents to subscribers and remembers the
+// last known state of every shard so that late subscribers can optionally
+// replay it.
+type shardEventBroker struct {
+	sync.Mutex
+
+	hostID      string
+	nextID      int
+	subscribers map[int]chan ShardEvent
+	lastState   map[uint32]shard.State
+}
+
+func newShardEventBroker(hostID string) *shardEventBroker {
+	return &shardEventBroker{
+		hostID:      hostID,
+		subscribers: make(map[int]chan ShardEvent),
+		lastState:   make(map[uint32]shard.State),
+	}
+}
+
+// subscribe registers a new subscriber, optionally replaying the last known
+// state of every shard this host has seen before delivering new events.
+func (b *shardEventBroker) subscribe(replayLastState bool) (<-chan ShardEvent, func()) {
+	b.Lock()
+	bufferSize := shardEventBufferSize
+	if replayLastState {
+		// Leave room for the full replay on top of the usual buffer.
+		bufferSize += len(b.lastState)
+	}
+	ch := make(chan ShardEvent, bufferSize)
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = ch
+
+	if replayLastState {
+		now := time.Now()
+		for shardID, state := range b.lastState {
+			select {
+			case ch <- ShardEvent{ShardID: shardID, From: state, To: state, HostID: b.hostID, Timestamp: now}:
+			default:
+			}
+		}
+	}
+	b.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.Lock()
+			if c, ok := b.subscribers[id]; ok {
+				delete(b.subscribers, id)
+				close(c)
+			}
+			b.Unlock()
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// publish records the shard's new state and fans the event out to every
+// subscriber, dropping the event for any subscriber whose buffer is full.
+func (b *shardEventBroker) publish(shardID uint32, from, to shard.State) {
+	b.publishEvent(ShardEvent{ShardID: shardID, From: from, To: to})
+}
+
+// publishRemoved records that a shard has left this host's shard set
+// entirely, as opposed to transitioning to another State, and fans out a
+// ShardEvent with Removed set so subscribers don't mistake the zero-value
+// To for a real state.
+func (b *shardEventBroker) publishRemoved(shardID uint32, from shard.State) {
+	b.publishEvent(ShardEvent{ShardID: shardID, From: from, Removed: true})
+}
+
+func (b *shardEventBroker) publishEvent(event ShardEvent) {
+	b.Lock()
+	defer b.Unlock()
+
+	event.HostID = b.hostID
+	event.Timestamp = time.Now()
+
+	if event.Removed {
+		delete(b.lastState, event.ShardID)
+	} else {
+		b.lastState[event.ShardID] = event.To
+	}
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber is behind, drop the event rather than block analysis.
+		}
+	}
+}
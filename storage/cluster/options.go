@@ -0,0 +1,72 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+// ClusterOptions is a set of options for a clustered database that are
+// orthogonal to the underlying storage.Options, e.g. hooks that observe or
+// steer cluster-level behavior such as startup bootstrapping.
+type ClusterOptions interface {
+	// SetStartupProgress sets the StartupProgress implementation used to
+	// report shard bootstrap and availability milestones.
+	SetStartupProgress(value StartupProgress) ClusterOptions
+
+	// StartupProgress returns the StartupProgress implementation.
+	StartupProgress() StartupProgress
+
+	// SetShardAvailabilityPolicy sets the policy used to decide when an
+	// initializing shard is marked available.
+	SetShardAvailabilityPolicy(value ShardAvailabilityPolicy) ClusterOptions
+
+	// ShardAvailabilityPolicy returns the shard availability policy.
+	ShardAvailabilityPolicy() ShardAvailabilityPolicy
+}
+
+type clusterOptions struct {
+	startupProgress         StartupProgress
+	shardAvailabilityPolicy ShardAvailabilityPolicy
+}
+
+// NewClusterOptions creates a new set of cluster options with defaults.
+func NewClusterOptions() ClusterOptions {
+	return &clusterOptions{
+		shardAvailabilityPolicy: AllNamespacesPolicy(),
+	}
+}
+
+func (o *clusterOptions) SetStartupProgress(value StartupProgress) ClusterOptions {
+	opts := *o
+	opts.startupProgress = value
+	return &opts
+}
+
+func (o *clusterOptions) StartupProgress() StartupProgress {
+	return o.startupProgress
+}
+
+func (o *clusterOptions) SetShardAvailabilityPolicy(value ShardAvailabilityPolicy) ClusterOptions {
+	opts := *o
+	opts.shardAvailabilityPolicy = value
+	return &opts
+}
+
+func (o *clusterOptions) ShardAvailabilityPolicy() ShardAvailabilityPolicy {
+	return o.shardAvailabilityPolicy
+}
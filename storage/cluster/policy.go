@@ -0,0 +1,168 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import (
+	"time"
+
+	"github.com/m3db/m3db/storage/namespace"
+)
+
+// ShardAvailabilityPolicy determines when an initializing shard is
+// considered bootstrapped enough to be marked available in the topology.
+// The default, AllNamespacesPolicy, requires every namespace to have
+// bootstrapped the shard, but deployments where some namespaces bootstrap
+// orders-of-magnitude slower than others may prefer a looser policy so that
+// hot namespaces can start serving reads sooner.
+type ShardAvailabilityPolicy interface {
+	// ShouldMarkAvailable returns whether a shard should be marked available
+	// given which namespaces have bootstrapped it so far.
+	ShouldMarkAvailable(
+		shardID uint32,
+		bootstrapped map[string]bool,
+		namespaces []namespace.Metadata,
+	) bool
+
+	// MinBootstrapDwellTime returns the minimum amount of time a shard must
+	// continuously satisfy ShouldMarkAvailable before it is actually marked
+	// available, to avoid flapping under fast topology updates.
+	MinBootstrapDwellTime() time.Duration
+}
+
+// AllNamespacesPolicy requires every namespace to have bootstrapped a shard
+// before it is marked available. This is the default, pre-existing behavior.
+func AllNamespacesPolicy() ShardAvailabilityPolicy {
+	return allNamespacesPolicy{}
+}
+
+type allNamespacesPolicy struct{}
+
+func (allNamespacesPolicy) ShouldMarkAvailable(
+	_ uint32,
+	bootstrapped map[string]bool,
+	namespaces []namespace.Metadata,
+) bool {
+	if len(bootstrapped) != len(namespaces) {
+		return false
+	}
+	for _, ok := range bootstrapped {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (allNamespacesPolicy) MinBootstrapDwellTime() time.Duration {
+	return 0
+}
+
+// QuorumNamespacesPolicy requires at least n namespaces to have bootstrapped
+// a shard before it is marked available.
+func QuorumNamespacesPolicy(n int) ShardAvailabilityPolicy {
+	return quorumNamespacesPolicy{quorum: n}
+}
+
+type quorumNamespacesPolicy struct {
+	quorum int
+}
+
+func (p quorumNamespacesPolicy) ShouldMarkAvailable(
+	_ uint32,
+	bootstrapped map[string]bool,
+	_ []namespace.Metadata,
+) bool {
+	count := 0
+	for _, ok := range bootstrapped {
+		if ok {
+			count++
+		}
+	}
+	return count >= p.quorum
+}
+
+func (quorumNamespacesPolicy) MinBootstrapDwellTime() time.Duration {
+	return 0
+}
+
+// NamespaceSubsetPolicy requires only the named namespaces to have
+// bootstrapped a shard before it is marked available, regardless of the
+// bootstrap state of any other namespace.
+func NamespaceSubsetPolicy(names ...string) ShardAvailabilityPolicy {
+	required := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		required[name] = struct{}{}
+	}
+	return namespaceSubsetPolicy{required: required}
+}
+
+type namespaceSubsetPolicy struct {
+	required map[string]struct{}
+}
+
+func (p namespaceSubsetPolicy) ShouldMarkAvailable(
+	_ uint32,
+	bootstrapped map[string]bool,
+	_ []namespace.Metadata,
+) bool {
+	if len(p.required) == 0 {
+		// A misconfigured (empty) subset must never vacuously mark a shard
+		// available before any namespace has actually bootstrapped it.
+		return false
+	}
+	for name := range p.required {
+		if !bootstrapped[name] {
+			return false
+		}
+	}
+	return true
+}
+
+func (namespaceSubsetPolicy) MinBootstrapDwellTime() time.Duration {
+	return 0
+}
+
+// DwellTimePolicy wraps another policy and additionally requires that a
+// shard continuously satisfy the wrapped policy for at least dwell before it
+// is marked available, to avoid flapping under fast topology updates.
+func DwellTimePolicy(inner ShardAvailabilityPolicy, dwell time.Duration) ShardAvailabilityPolicy {
+	return dwellTimePolicy{inner: inner, dwell: dwell}
+}
+
+type dwellTimePolicy struct {
+	inner ShardAvailabilityPolicy
+	dwell time.Duration
+}
+
+func (p dwellTimePolicy) ShouldMarkAvailable(
+	shardID uint32,
+	bootstrapped map[string]bool,
+	namespaces []namespace.Metadata,
+) bool {
+	return p.inner.ShouldMarkAvailable(shardID, bootstrapped, namespaces)
+}
+
+func (p dwellTimePolicy) MinBootstrapDwellTime() time.Duration {
+	if inner := p.inner.MinBootstrapDwellTime(); inner > p.dwell {
+		return inner
+	}
+	return p.dwell
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3x/log"
+)
+
+// defaultStartupProgressReportInterval is how often the default
+// StartupProgress implementation logs a bootstrap summary.
+const defaultStartupProgressReportInterval = 30 * time.Second
+
+// StartupProgress is notified of shard bootstrap and availability milestones
+// while a clusterDB is cold starting, so that operators (and metrics
+// emitters) have a way to observe a long bootstrap instead of watching a
+// silent log.
+type StartupProgress interface {
+	// AddShard registers a shard that is expected to bootstrap.
+	AddShard(id uint32)
+
+	// ShardBootstrapped marks a shard as bootstrapped for a given namespace.
+	ShardBootstrapped(id uint32, namespace string)
+
+	// ShardAvailable marks a shard as having been marked available in the
+	// topology.
+	ShardAvailable(id uint32)
+
+	// BootstrapCompleted signals that there are no more shards pending
+	// bootstrap or availability.
+	BootstrapCompleted()
+
+	// Close stops any background reporting started by this StartupProgress.
+	// It is safe to call even if BootstrapCompleted was never called, and
+	// safe to call more than once.
+	Close()
+}
+
+// NewLogStartupProgress returns a StartupProgress implementation that
+// periodically logs a summary of how many shards have bootstrapped and
+// become available, along with an estimate of the time remaining.
+func NewLogStartupProgress(log xlog.Logger) StartupProgress {
+	p := &logStartupProgress{
+		log:          log,
+		start:        time.Now(),
+		shards:       make(map[uint32]struct{}),
+		bootstrapped: make(map[uint32]map[string]struct{}),
+		available:    make(map[uint32]struct{}),
+		doneCh:       make(chan struct{}),
+	}
+	go p.reportLoop()
+	return p
+}
+
+type logStartupProgress struct {
+	sync.Mutex
+
+	log   xlog.Logger
+	start time.Time
+
+	shards       map[uint32]struct{}
+	bootstrapped map[uint32]map[string]struct{}
+	available    map[uint32]struct{}
+
+	completed bool
+	doneCh    chan struct{}
+}
+
+func (p *logStartupProgress) AddShard(id uint32) {
+	p.Lock()
+	defer p.Unlock()
+	p.shards[id] = struct{}{}
+}
+
+func (p *logStartupProgress) ShardBootstrapped(id uint32, namespace string) {
+	p.Lock()
+	defer p.Unlock()
+	namespaces, ok := p.bootstrapped[id]
+	if !ok {
+		namespaces = make(map[string]struct{})
+		p.bootstrapped[id] = namespaces
+	}
+	namespaces[namespace] = struct{}{}
+}
+
+func (p *logStartupProgress) ShardAvailable(id uint32) {
+	p.Lock()
+	defer p.Unlock()
+	p.available[id] = struct{}{}
+}
+
+func (p *logStartupProgress) BootstrapCompleted() {
+	p.Lock()
+	if p.completed {
+		p.Unlock()
+		return
+	}
+	p.completed = true
+	p.Unlock()
+
+	close(p.doneCh)
+	p.report()
+}
+
+func (p *logStartupProgress) Close() {
+	p.Lock()
+	if p.completed {
+		p.Unlock()
+		return
+	}
+	p.completed = true
+	p.Unlock()
+
+	close(p.doneCh)
+}
+
+func (p *logStartupProgress) reportLoop() {
+	ticker := time.NewTicker(defaultStartupProgressReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.report()
+		case <-p.doneCh:
+			return
+		}
+	}
+}
+
+func (p *logStartupProgress) report() {
+	p.Lock()
+	defer p.Unlock()
+
+	total := len(p.shards)
+	if total == 0 {
+		return
+	}
+
+	namespaces := make(map[string]struct{})
+	for _, ns := range p.bootstrapped {
+		for n := range ns {
+			namespaces[n] = struct{}{}
+		}
+	}
+
+	available := len(p.available)
+	if available == 0 {
+		p.log.Infof("bootstrap progress: 0 of %d shards available, %d namespaces reporting",
+			total, len(namespaces))
+		return
+	}
+
+	elapsed := time.Since(p.start)
+	perShard := elapsed / time.Duration(available)
+	remaining := perShard * time.Duration(total-available)
+	p.log.Infof("bootstrap progress: %d of %d shards available across %d namespaces, est. time remaining %v",
+		available, total, len(namespaces), remaining)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import "github.com/m3db/m3db/storage"
+
+// Database is a clustered time series database that wraps a storage.Database
+// with awareness of the cluster topology, driving shard bootstrap and
+// availability off topology changes for the host it runs on.
+type Database interface {
+	storage.Database
+
+	// SubscribeShardEvents returns a channel of ShardEvent for every shard
+	// state transition this host observes for itself, along with an
+	// unsubscribe function that must be called to release the subscription.
+	// If replayLastState is true, the last known state of every shard this
+	// host has seen is replayed onto the channel before any new event, so a
+	// late subscriber can reconcile without missing earlier transitions.
+	SubscribeShardEvents(replayLastState bool) (<-chan ShardEvent, func())
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// initWarnGracePeriod is how long maybeWarnAboutInit waits after the
+	// cluster database is constructed before it starts checking whether this
+	// host looks like it was never added to a placement.
+	initWarnGracePeriod = 30 * time.Second
+
+	// initWarnMinBackoff and initWarnMaxBackoff bound the backoff between
+	// repeated warnings once the grace period has elapsed.
+	initWarnMinBackoff = 5 * time.Second
+	initWarnMaxBackoff = 60 * time.Second
+)
+
+// maybeWarnAboutInit periodically checks whether this host looks like it is
+// either waiting to be added to an existing placement, or running against a
+// placement that was never initialized at all, and logs a prominent warning
+// distinguishing the two cases. Unlike the one-shot "topology has no shard
+// set for host ID" log line emitted by hostOrEmptyShardSet, this keeps
+// warning (with backoff) for as long as the condition persists, since a
+// single log line at startup is easy to miss in a long cold-start.
+func (d *clusterDB) maybeWarnAboutInit() {
+	timer := time.NewTimer(initWarnGracePeriod)
+	defer timer.Stop()
+
+	backoff := initWarnMinBackoff
+	for {
+		select {
+		case <-d.warnDoneCh:
+			return
+		case <-timer.C:
+		}
+
+		if hostShardSet, ok := d.watch.Get().LookupHostShardSet(d.hostID); ok && len(hostShardSet.ShardSet().All()) > 0 {
+			// This host has shards assigned, nothing left to warn about.
+			return
+		}
+
+		d.log.Warnf("%s", d.describeUninitializedCluster())
+
+		timer.Reset(backoff)
+		backoff *= 2
+		if backoff > initWarnMaxBackoff {
+			backoff = initWarnMaxBackoff
+		}
+	}
+}
+
+// describeUninitializedCluster distinguishes between a host that is simply
+// waiting to be added to an existing placement and a placement that appears
+// to have never been initialized at all.
+func (d *clusterDB) describeUninitializedCluster() string {
+	m := d.watch.Get()
+
+	_, hostKnown := m.LookupHostShardSet(d.hostID)
+	otherHosts := false
+	anyShardsAssigned := false
+	for _, hss := range m.HostShardSets() {
+		if hss.Host().ID() != d.hostID {
+			otherHosts = true
+		}
+		if len(hss.ShardSet().All()) > 0 {
+			anyShardsAssigned = true
+		}
+	}
+
+	if !hostKnown && !otherHosts && !anyShardsAssigned {
+		return fmt.Sprintf("cluster db: placement never initialized for host ID %s "+
+			"(topology has no hosts and no shards assigned) -- "+
+			"did you forget to run the placement-init command?", d.hostID)
+	}
+
+	return fmt.Sprintf("cluster db: host ID %s has no shards assigned yet, "+
+		"waiting to be added to existing placement", d.hostID)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/m3db/m3db/sharding"
+	"github.com/m3db/m3db/storage/namespace"
+)
+
+// NewDatabase creates a new single-node time series database for the given
+// namespaces, starting with shardSet as its initial set of owned shards.
+func NewDatabase(
+	namespaces []namespace.Metadata,
+	shardSet sharding.ShardSet,
+	opts Options,
+) (Database, error) {
+	return &database{
+		opts:       opts,
+		namespaces: namespaces,
+		shardSet:   shardSet,
+	}, nil
+}
+
+type database struct {
+	sync.RWMutex
+
+	opts       Options
+	namespaces []namespace.Metadata
+	shardSet   sharding.ShardSet
+	listeners  []BootstrapListener
+}
+
+func (d *database) Options() Options {
+	return d.opts
+}
+
+func (d *database) Namespaces() []namespace.Metadata {
+	d.RLock()
+	defer d.RUnlock()
+	return d.namespaces
+}
+
+func (d *database) Open() error {
+	return nil
+}
+
+func (d *database) Close() error {
+	return nil
+}
+
+func (d *database) AssignShardSetWithContext(
+	shardSet sharding.ShardSet,
+	shardCtxs map[uint32]context.Context,
+) {
+	d.Lock()
+	defer d.Unlock()
+	d.shardSet = shardSet
+	// This implementation only records shardSet. Neither Open nor this
+	// method bootstraps any shard, shardCtxs is not used, and the listeners
+	// added through RegisterBootstrapListener are never invoked.
+}
+
+func (d *database) RegisterBootstrapListener(listener BootstrapListener) {
+	d.Lock()
+	defer d.Unlock()
+	d.listeners = append(d.listeners, listener)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/m3db/m3db/sharding"
+	"github.com/m3db/m3db/storage/namespace"
+	"github.com/m3db/m3x/instrument"
+)
+
+// Database is a single-node time series database, responsible for
+// bootstrapping and serving reads and writes for whatever shards it is
+// currently assigned.
+type Database interface {
+	// Options returns the database options.
+	Options() Options
+
+	// Namespaces returns the namespaces this database owns.
+	Namespaces() []namespace.Metadata
+
+	// Open starts the database bootstrapping its assigned shards and
+	// accepting reads and writes.
+	Open() error
+
+	// Close stops the database.
+	Close() error
+
+	// AssignShardSetWithContext assigns shardSet as the set of shards this
+	// database is responsible for. shardCtxs supplies, for every shard that
+	// is currently bootstrapping, a context that is cancelled once the
+	// shard is no longer this host's responsibility to bootstrap (e.g. the
+	// topology reassigned it elsewhere); a shard whose context is cancelled
+	// should abandon its in-flight bootstrap rather than run it to
+	// completion against data this host no longer needs.
+	AssignShardSetWithContext(shardSet sharding.ShardSet, shardCtxs map[uint32]context.Context)
+
+	// RegisterBootstrapListener registers a listener to be invoked every
+	// time a shard finishes bootstrapping a namespace. Listeners are
+	// invoked on whatever goroutine completed the bootstrap and must not
+	// block.
+	RegisterBootstrapListener(listener BootstrapListener)
+}
+
+// BootstrapListener is notified every time a shard finishes bootstrapping a
+// single namespace.
+type BootstrapListener func(shardID uint32, namespaceID string)
+
+// Options represents the options for constructing a database.
+type Options interface {
+	// InstrumentOptions returns the instrumentation options.
+	InstrumentOptions() instrument.Options
+}